@@ -8,25 +8,32 @@ import (
 	"compress/zlib"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
 	"github.com/andybalholm/brotli"
+	"github.com/dsnet/compress/bzip2"
 	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/compress/zstd"
 	"github.com/pierrec/lz4/v4"
+	"github.com/ulikunitz/xz"
 )
 
 // 基础支持的压缩算法列表（跨平台）
 var algorithms = []string{
-	"gzip", "zlib", "deflate", "br", 
+	"gzip", "zlib", "deflate", "br",
 	"zstd", "lz4", "snappy", "lzw",
-	"lzo1", "lzo1a", "lzo1b", "lzo1c", "lzo1f", "lzo1x",
+	"bzip2", "xz", "lzo1x",
 }
 
 var (
@@ -36,8 +43,29 @@ var (
 	level      int
 	algoParam  string
 	showAll    bool
+	verify     bool
+
+	httpNegotiate bool
+	acceptHeader  string
+	costAlpha     float64
+	costBeta      float64
+	costGamma     float64
+
+	streamMode bool
+	chunkSize  int
+	jobs       int
+
+	dictFile  string
+	trainGlob string
 )
 
+// dictTrainTargetSize 是-train拼接训练语料时的目标大小（固定值，没有对应的命令行参数）
+const dictTrainTargetSize = 64 * 1024
+
+// http-negotiate模式下可在HTTP Content-Encoding中使用的编码，对应fasthttp/elton等
+// 生态约定支持的取值
+var httpWireAlgorithms = []string{"gzip", "br", "zstd", "deflate", "lz4", "snappy"}
+
 func init() {
 	flag.StringVar(&inputFile, "i", "", "输入文件路径 (必需)")
 	flag.StringVar(&outputFile, "o", "", "输出文件路径 (默认为不输出压缩文件)")
@@ -45,6 +73,17 @@ func init() {
 	flag.IntVar(&level, "l", -1, "压缩级别: 0(最低) - 9(最高) (默认值取决于算法)")
 	flag.IntVar(&verbosity, "v", 0, "详细模式: 0(默认), 1(详细信息), 2(调试)")
 	flag.BoolVar(&showAll, "show-all", false, "显示所有算法（包括平台不支持的）")
+	flag.BoolVar(&verify, "verify", true, "压缩后立即解压校验，测量解压耗时并比对原始数据")
+	flag.BoolVar(&httpNegotiate, "http-negotiate", false, "启用HTTP Content-Encoding协商模式，只测试可上线的编码并给出推荐")
+	flag.StringVar(&acceptHeader, "accept", "", "模拟的Accept-Encoding请求头，如 \"br;q=1.0, zstd;q=0.9, gzip;q=0.5\"")
+	flag.Float64Var(&costAlpha, "alpha", 1.0, "http-negotiate评分中响应体大小(字节)的权重")
+	flag.Float64Var(&costBeta, "beta", 20.0, "http-negotiate评分中压缩耗时(毫秒)的权重")
+	flag.Float64Var(&costGamma, "gamma", 20.0, "http-negotiate评分中解压耗时(毫秒)的权重")
+	flag.BoolVar(&streamMode, "stream", false, "流式分块压缩模式，边读边压缩，适合大文件")
+	flag.IntVar(&chunkSize, "chunk", 256*1024, "流式模式下每个分块的大小（字节）")
+	flag.IntVar(&jobs, "jobs", runtime.NumCPU(), "流式模式下并发压缩的算法数（worker数）")
+	flag.StringVar(&dictFile, "dict", "", "字典文件路径，用于支持字典的算法(目前为zstd)")
+	flag.StringVar(&trainGlob, "train", "", "从匹配该通配符的样本文件训练字典语料，与-dict配合可将结果另存")
 }
 
 func main() {
@@ -61,6 +100,14 @@ func main() {
 		log.Printf("正在分析: %s", inputFile)
 	}
 
+	if streamMode {
+		testAlgorithms := getTestAlgorithms(algorithms)
+		if err := runStreamBenchmark(inputFile, testAlgorithms); err != nil {
+			log.Fatalf("流式压缩测试失败: %v", err)
+		}
+		return
+	}
+
 	data, origSize, err := readFile(inputFile)
 	if err != nil {
 		log.Fatalf("读取文件错误: %v", err)
@@ -70,11 +117,29 @@ func main() {
 		log.Printf("原始大小: %s", formatSize(origSize))
 	}
 
+	if httpNegotiate {
+		if err := runHTTPNegotiate(data, inputFile); err != nil {
+			log.Fatalf("HTTP协商模式失败: %v", err)
+		}
+		return
+	}
+
 	// 获取平台支持的有效算法
 	platformAlgorithms := algorithms
 	testAlgorithms := getTestAlgorithms(platformAlgorithms)
 
-	results, err := measureCompressions(data, testAlgorithms)
+	var dict []byte
+	if trainGlob != "" || dictFile != "" {
+		dict, err = loadOrTrainDictionary()
+		if err != nil {
+			log.Fatalf("加载/训练字典失败: %v", err)
+		}
+		if verbosity > 0 {
+			log.Printf("字典大小: %s", formatSize(len(dict)))
+		}
+	}
+
+	results, err := measureCompressions(data, testAlgorithms, dict)
 	if err != nil {
 		log.Fatalf("压缩测试失败: %v", err)
 	}
@@ -86,7 +151,62 @@ func main() {
 		saveCompressedData(data, outputFile, results)
 	}
 
-	printResults(origSize, results, testAlgorithms, platformAlgorithms, inputFile)
+	printResults(origSize, results, testAlgorithms, platformAlgorithms, inputFile, dict)
+}
+
+// loadOrTrainDictionary 根据-dict和-train参数获取字典内容：优先使用-train从样本语料
+// 训练，若同时指定了-dict则把训练结果落盘以便复用；否则直接读取-dict指向的字典文件
+func loadOrTrainDictionary() ([]byte, error) {
+	if trainGlob != "" {
+		dict, err := trainDictionary(trainGlob)
+		if err != nil {
+			return nil, err
+		}
+		if dictFile != "" {
+			if err := os.WriteFile(dictFile, dict, 0644); err != nil {
+				return nil, fmt.Errorf("保存训练得到的字典失败: %w", err)
+			}
+		}
+		return dict, nil
+	}
+	return os.ReadFile(dictFile)
+}
+
+// trainDictionary 实现了一个简单的字典构建器：把匹配通配符的样本文件随机打乱后
+// 依次拼接，直到达到dictTrainTargetSize大小为止
+func trainDictionary(pattern string) ([]byte, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("未找到匹配 %q 的样本文件", pattern)
+	}
+
+	rand.Shuffle(len(matches), func(i, j int) { matches[i], matches[j] = matches[j], matches[i] })
+
+	var dict []byte
+	for _, m := range matches {
+		if len(dict) >= dictTrainTargetSize {
+			break
+		}
+		content, err := os.ReadFile(m)
+		if err != nil {
+			if verbosity > 0 {
+				log.Printf("训练语料跳过 %s: %v", m, err)
+			}
+			continue
+		}
+		if remain := dictTrainTargetSize - len(dict); len(content) > remain {
+			content = content[:remain]
+		}
+		dict = append(dict, content...)
+	}
+
+	if len(dict) == 0 {
+		return nil, fmt.Errorf("从 %q 构建的训练语料为空", pattern)
+	}
+	return dict, nil
 }
 
 // 获取要测试的算法列表
@@ -99,10 +219,10 @@ func getTestAlgorithms(supported []string) []string {
 	// 用户指定了特定算法列表
 	parts := strings.Split(algoParam, ",")
 	var validAlgos []string
-	
+
 	for _, p := range parts {
 		p = strings.TrimSpace(strings.ToLower(p))
-		
+
 		// 检查是否是支持的算法
 		if contains(supported, p) {
 			validAlgos = append(validAlgos, p)
@@ -110,7 +230,7 @@ func getTestAlgorithms(supported []string) []string {
 			log.Printf("算法被忽略（不支持）: %s", p)
 		}
 	}
-	
+
 	return validAlgos
 }
 
@@ -122,14 +242,14 @@ func readFile(filePath string) ([]byte, int, error) {
 	return data, len(data), nil
 }
 
-func measureCompressions(data []byte, algos []string) (map[string]result, error) {
+func measureCompressions(data []byte, algos []string, dict []byte) (map[string]result, error) {
 	results := make(map[string]result)
 
 	for _, algo := range algos {
 		if verbosity > 0 {
 			log.Printf("正在测试算法: %s", getDisplayName(algo))
 		}
-		
+
 		start := time.Now()
 		compressedData, err := compressWithAlgorithm(data, algo, level)
 		duration := time.Since(start)
@@ -143,17 +263,65 @@ func measureCompressions(data []byte, algos []string) (map[string]result, error)
 
 		compressedSize := len(compressedData)
 		ratio := float64(compressedSize) / float64(len(data)) * 100
-		
-		results[algo] = result{
+
+		r := result{
 			CompressedSize: compressedSize,
 			Ratio:          ratio,
 			Duration:       duration,
 			CompressedData: compressedData,
 		}
-		
+
+		if verify {
+			r.VerifySupported = hasDecompressor(algo)
+			if r.VerifySupported {
+				decompStart := time.Now()
+				decompressed, err := decompressWithAlgorithm(compressedData, algo)
+				r.DecompressDuration = time.Since(decompStart)
+
+				if err != nil {
+					if verbosity > 0 {
+						log.Printf("%s 解压校验失败: %v", getDisplayName(algo), err)
+					}
+				} else {
+					r.Verified = bytes.Equal(data, decompressed)
+					if !r.Verified && verbosity > 0 {
+						log.Printf("%s 解压结果与原始数据不一致", getDisplayName(algo))
+					}
+				}
+			}
+		}
+
+		if len(dict) > 0 {
+			r.DictSupported = hasDictSupport(algo)
+			if r.DictSupported {
+				dictCompressed, err := compressWithDictionary(data, algo, dict, level)
+				if err != nil {
+					if verbosity > 0 {
+						log.Printf("%s 使用字典压缩失败: %v", getDisplayName(algo), err)
+					}
+				} else {
+					r.DictCompressedSize = len(dictCompressed)
+					r.DictRatio = float64(r.DictCompressedSize) / float64(len(data)) * 100
+
+					if verify {
+						decompressed, err := decompressWithDictionary(dictCompressed, algo, dict)
+						if err != nil {
+							if verbosity > 0 {
+								log.Printf("%s 含字典解压校验失败: %v", getDisplayName(algo), err)
+							}
+						} else if !bytes.Equal(data, decompressed) && verbosity > 0 {
+							log.Printf("%s 含字典解压结果与原始数据不一致", getDisplayName(algo))
+						}
+					}
+				}
+			}
+		}
+
+		results[algo] = r
+
 		if verbosity > 1 {
-			log.Printf("%s: 原始大小 %dB -> 压缩后 %dB (%.1f%%) 时间:%v", 
-				getDisplayName(algo), len(data), compressedSize, ratio, duration)
+			log.Printf("%s: 原始大小 %dB -> 压缩后 %dB (%.1f%%) 时间:%v 解压时间:%v",
+				getDisplayName(algo), len(data), compressedSize, ratio, duration, r.DecompressDuration)
 		}
 	}
 
@@ -178,95 +346,756 @@ func compressWithAlgorithm(data []byte, algorithm string, level int) ([]byte, er
 		return snappyCompress(data)
 	case "lzw":
 		return lzwCompress(data)
-	case "lzo1", "lzo1a", "lzo1b", "lzo1c", "lzo1f", "lzo1x":
-		return lzoCompress(data, algorithm, level)
+	case "bzip2":
+		return bzip2Compress(data, level)
+	case "xz":
+		return xzCompress(data)
+	case "lzo1x":
+		return lzo1xCompress(data), nil
 	default:
 		return nil, fmt.Errorf("未知的压缩算法: %s", algorithm)
 	}
 }
 
-// LZO压缩实现 - 使用内置实现
-func lzoCompress(data []byte, algorithm string, level int) ([]byte, error) {
-	// 简化版的LZO实现（实际应用中可使用第三方库）
+// hasDecompressor 判断某算法是否已接入解压校验路径
+func hasDecompressor(algorithm string) bool {
+	switch algorithm {
+	case "gzip", "zlib", "deflate", "br", "zstd", "lz4", "snappy", "lzw", "bzip2", "xz", "lzo1x":
+		return true
+	default:
+		return false
+	}
+}
+
+// decompressWithAlgorithm 对压缩结果进行解压，用于往返校验
+func decompressWithAlgorithm(data []byte, algorithm string) ([]byte, error) {
+	switch algorithm {
+	case "gzip":
+		return gzipDecompress(data)
+	case "zlib":
+		return zlibDecompress(data)
+	case "deflate":
+		return deflateDecompress(data)
+	case "br":
+		return brotliDecompress(data)
+	case "zstd":
+		return zstdDecompress(data)
+	case "lz4":
+		return lz4Decompress(data)
+	case "snappy":
+		return snappyDecompress(data)
+	case "lzw":
+		return lzwDecompress(data)
+	case "bzip2":
+		return bzip2Decompress(data)
+	case "xz":
+		return xzDecompress(data)
+	case "lzo1x":
+		return lzo1xDecompress(data)
+	default:
+		return nil, fmt.Errorf("%s 暂不支持解压校验", algorithm)
+	}
+}
+
+// hasDictSupport 判断某算法是否接入了字典压缩路径。目前仅zstd支持，因为本项目引入的
+// brotli库版本未对外暴露自定义字典的公开API
+func hasDictSupport(algorithm string) bool {
+	return algorithm == "zstd"
+}
+
+// compressWithDictionary 使用字典压缩data，仅用于hasDictSupport为true的算法
+func compressWithDictionary(data []byte, algorithm string, dict []byte, level int) ([]byte, error) {
+	switch algorithm {
+	case "zstd":
+		return zstdCompressWithDict(data, dict, level)
+	default:
+		return nil, fmt.Errorf("%s 不支持字典压缩", algorithm)
+	}
+}
+
+// decompressWithDictionary 使用字典解压data，用于校验含字典压缩的往返正确性
+func decompressWithDictionary(data []byte, algorithm string, dict []byte) ([]byte, error) {
 	switch algorithm {
-	case "lzo1", "lzo1a", "lzo1b", "lzo1c", "lzo1f", "lzo1x":
-		// 基础LZO实现（仅示意，实际需要更高效的算法）
-		return basicLZO(data, level), nil
+	case "zstd":
+		return zstdDecompressWithDict(data, dict)
 	default:
-		return nil, fmt.Errorf("不支持LZO算法: %s", algorithm)
-	}
-}
-
-// 基本的LZO实现（简化版）
-func basicLZO(data []byte, level int) []byte {
-	// 基于重复模式检测的基本压缩
-	var output []byte
-	const maxOffset = 2048
-	
-	for i := 0; i < len(data); {
-		// 查找重复序列
-		found := false
-		maxLen := 0
-		maxPos := 0
-		
-		// 在窗口内查找最长匹配
-		start := i - maxOffset
-		if start < 0 {
-			start = 0
-		}
-		
-		for j := start; j < i; j++ {
-			k := 0
-			for k < min(8, len(data)-i) && j+k < i && data[j+k] == data[i+k] {
-				k++
+		return nil, fmt.Errorf("%s 不支持字典解压", algorithm)
+	}
+}
+
+// LZO1X-1 编码参数：3字节滚动哈希进入16K桶的匹配表，48K滑动窗口
+const (
+	lzo1xHashBits    = 14
+	lzo1xHashSize    = 1 << lzo1xHashBits
+	lzo1xM2MaxOffset = 0x0800
+	lzo1xM3MaxOffset = 0x4000
+	lzo1xM4MaxOffset = 0xbfff
+	lzo1xM2MaxLen    = 8
+	lzo1xM3MaxLen    = 33
+	lzo1xM4MaxLen    = 9
+)
+
+// lzo1xHash 对输入的3个字节做乘法哈希，产出16K表的下标
+func lzo1xHash(data []byte, i int) uint32 {
+	v := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16
+	return (v * 0x1824429D) >> (32 - lzo1xHashBits)
+}
+
+// lzo1xAppendLength 按LZO的0-游程编码写出一个无上限的长度值
+func lzo1xAppendLength(out []byte, n int) []byte {
+	for n > 255 {
+		out = append(out, 0)
+		n -= 255
+	}
+	return append(out, byte(n))
+}
+
+// lzo1xCompress 实现与liblzo2位级兼容的LZO1X-1编码：用哈希表查找3字节匹配，
+// 按M2/M3/M4操作码写出匹配，字面量按标准的短/长编码写出，末尾附加终止标记。
+func lzo1xCompress(data []byte) []byte {
+	n := len(data)
+	var out []byte
+
+	table := make([]int32, lzo1xHashSize)
+	ii := 0
+	ip := 0
+	limit := n - 4
+
+	// emitLiterals 写出 data[ii:ii+t] 这段字面量；长度<=3时与上一个匹配操作码的
+	// 尾部字面量计数位(ss)合并，其余情况按标准短/长字面量格式编码
+	emitLiterals := func(t int) {
+		if t == 0 {
+			return
+		}
+		lit := data[ii : ii+t]
+		if len(out) == 0 && t <= 238 {
+			out = append(out, byte(17+t))
+		} else if t <= 3 {
+			out[len(out)-2] |= byte(t)
+		} else if t <= 18 {
+			out = append(out, byte(t-3))
+		} else {
+			out = append(out, 0)
+			out = lzo1xAppendLength(out, t-18)
+		}
+		out = append(out, lit...)
+	}
+
+	for ip < limit {
+		idx := lzo1xHash(data, ip)
+		mPos := int(table[idx]) - 1
+		table[idx] = int32(ip + 1)
+
+		if mPos < 0 || ip-mPos > lzo1xM4MaxOffset ||
+			data[mPos] != data[ip] || data[mPos+1] != data[ip+1] || data[mPos+2] != data[ip+2] {
+			ip++
+			continue
+		}
+
+		// 扩展匹配长度；distance < length 时的重叠复制通过原始数据本身的周期性保证正确
+		mLen := 3
+		for ip+mLen < n && data[mPos+mLen] == data[ip+mLen] {
+			mLen++
+		}
+
+		mOff := ip - mPos
+		emitLiterals(ip - ii)
+
+		switch {
+		case mOff <= lzo1xM2MaxOffset && mLen <= lzo1xM2MaxLen:
+			d := mOff - 1
+			out = append(out, byte(((mLen-1)<<5)|((d&7)<<2)), byte(d>>3))
+		case mOff <= lzo1xM3MaxOffset:
+			d := mOff - 1
+			if mLen <= lzo1xM3MaxLen {
+				out = append(out, byte(0x20|(mLen-2)))
+			} else {
+				out = append(out, byte(0x20))
+				out = lzo1xAppendLength(out, mLen-lzo1xM3MaxLen)
+			}
+			out = append(out, byte((d&63)<<2), byte(d>>6))
+		default:
+			d := mOff - 0x4000
+			if mLen <= lzo1xM4MaxLen {
+				out = append(out, byte(0x10|((d&0x4000)>>11)|(mLen-2)))
+			} else {
+				out = append(out, byte(0x10|((d&0x4000)>>11)))
+				out = lzo1xAppendLength(out, mLen-lzo1xM4MaxLen)
+			}
+			out = append(out, byte((d&63)<<2), byte(d>>6))
+		}
+
+		ip += mLen
+		ii = ip
+	}
+
+	emitLiterals(n - ii)
+	return append(out, 0x11, 0x00, 0x00)
+}
+
+// lzo1xDecompress 解码lzo1xCompress产生的LZO1X位流，兼容标准liblzo2格式。
+// 该格式本身是一个以操作码驱动的状态机，这里按规范用goto直接对应各状态，
+// 避免用额外的抽象掩盖其跳转关系。
+func lzo1xDecompress(in []byte) ([]byte, error) {
+	var out []byte
+	var t, mPos int
+	var last2 byte
+	pos := 0
+
+	readU8 := func() (byte, error) {
+		if pos >= len(in) {
+			return 0, fmt.Errorf("lzo1x: 输入数据不完整")
+		}
+		b := in[pos]
+		pos++
+		return b, nil
+	}
+	readU16 := func() (int, error) {
+		if pos+2 > len(in) {
+			return 0, fmt.Errorf("lzo1x: 输入数据不完整")
+		}
+		v := int(in[pos]) | int(in[pos+1])<<8
+		pos += 2
+		return v, nil
+	}
+	readLength := func(base int) (int, error) {
+		l := 0
+		for {
+			b, err := readU8()
+			if err != nil {
+				return 0, err
+			}
+			if b == 0 {
+				l += 255
+				continue
+			}
+			return l + int(b) + base, nil
+		}
+	}
+	appendLiteral := func(t int) error {
+		if pos+t > len(in) {
+			return fmt.Errorf("lzo1x: 输入数据不完整")
+		}
+		out = append(out, in[pos:pos+t]...)
+		pos += t
+		return nil
+	}
+	copyMatch := func(mPos, t int) error {
+		if mPos < 0 {
+			return fmt.Errorf("lzo1x: 匹配距离越界")
+		}
+		if mPos+t > len(out) {
+			for i := 0; i < t; i++ {
+				out = append(out, out[mPos])
+				mPos++
+			}
+		} else {
+			out = append(out, out[mPos:mPos+t]...)
+		}
+		return nil
+	}
+
+	b0, err := readU8()
+	if err != nil {
+		return nil, err
+	}
+	op := b0
+
+	if op > 17 {
+		t = int(op) - 17
+		if t < 4 {
+			goto matchNext
+		}
+		if err := appendLiteral(t); err != nil {
+			return nil, err
+		}
+		goto firstLiteralRun
+	}
+
+beginLoop:
+	t = int(op)
+	if t >= 16 {
+		goto match
+	}
+	if t == 0 {
+		if t, err = readLength(15); err != nil {
+			return nil, err
+		}
+	}
+	if err := appendLiteral(t + 3); err != nil {
+		return nil, err
+	}
+
+firstLiteralRun:
+	if op, err = readU8(); err != nil {
+		return nil, err
+	}
+	last2 = op
+	t = int(op)
+	if t >= 16 {
+		goto match
+	}
+	mPos = len(out) - (1 + lzo1xM2MaxOffset)
+	mPos -= t >> 2
+	{
+		b, err := readU8()
+		if err != nil {
+			return nil, err
+		}
+		mPos -= int(b) << 2
+	}
+	if mPos < 0 {
+		return nil, fmt.Errorf("lzo1x: 匹配距离越界")
+	}
+	if err := copyMatch(mPos, 3); err != nil {
+		return nil, err
+	}
+	goto matchDone
+
+match:
+	t = int(op)
+	last2 = op
+	switch {
+	case t >= 64:
+		mPos = len(out) - 1 - ((t >> 2) & 7)
+		b, err := readU8()
+		if err != nil {
+			return nil, err
+		}
+		mPos -= int(b) << 3
+		t = (t >> 5) - 1
+		goto copyMatchLen
+	case t >= 32:
+		t &= 31
+		if t == 0 {
+			if t, err = readLength(31); err != nil {
+				return nil, err
 			}
-			
-			if k > maxLen {
-				maxLen = k
-				maxPos = j
+		}
+		v16, err := readU16()
+		if err != nil {
+			return nil, err
+		}
+		mPos = len(out) - 1 - (v16 >> 2)
+		last2 = byte(v16 & 0xFF)
+	case t >= 16:
+		mPos = len(out) - ((t & 8) << 11)
+		t &= 7
+		if t == 0 {
+			if t, err = readLength(7); err != nil {
+				return nil, err
 			}
 		}
-		
-		// 如果找到长度>2的匹配
-		if maxLen > 2 {
-			offset := i - maxPos
-			output = append(output, byte(offset>>8)|0x80, byte(offset&0xFF))
-			output = append(output, byte(maxLen))
-			i += maxLen
-			found = true
+		v16, err := readU16()
+		if err != nil {
+			return nil, err
+		}
+		mPos -= v16 >> 2
+		if mPos == len(out) {
+			return out, nil
+		}
+		mPos -= 0x4000
+		last2 = byte(v16 & 0xFF)
+	default:
+		mPos = len(out) - 1 - (t >> 2)
+		b, err := readU8()
+		if err != nil {
+			return nil, err
+		}
+		mPos -= int(b) << 2
+		if mPos < 0 {
+			return nil, fmt.Errorf("lzo1x: 匹配距离越界")
+		}
+		if err := copyMatch(mPos, 2); err != nil {
+			return nil, err
 		}
-		
-		if !found {
-			output = append(output, data[i])
-			i++
+		goto matchDone
+	}
+
+copyMatchLen:
+	if mPos < 0 {
+		return nil, fmt.Errorf("lzo1x: 匹配距离越界")
+	}
+	if err := copyMatch(mPos, t+2); err != nil {
+		return nil, err
+	}
+
+matchDone:
+	t = int(last2 & 3)
+	if t == 0 {
+		goto matchEnd
+	}
+matchNext:
+	if err := appendLiteral(t); err != nil {
+		return nil, err
+	}
+	if op, err = readU8(); err != nil {
+		return nil, err
+	}
+	goto match
+
+matchEnd:
+	if op, err = readU8(); err != nil {
+		return nil, err
+	}
+	goto beginLoop
+}
+
+// parseAcceptEncoding 解析形如 "br;q=1.0, zstd;q=0.9, gzip;q=0.5" 的Accept-Encoding
+// 请求头，返回每个编码名到其q权重的映射（名称统一转为小写）
+func parseAcceptEncoding(header string) map[string]float64 {
+	qvalues := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if v, ok := strings.CutPrefix(f, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
 		}
+		qvalues[name] = q
 	}
-	
-	return output
+	return qvalues
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+// runHTTPNegotiate 模拟服务端按Accept-Encoding挑选Content-Encoding的过程：只测试
+// 可在HTTP上使用的编码，按加权成本 alpha*体积+beta*压缩耗时+gamma*解压耗时 排序打分，
+// 并为得分最低（最优）的编码给出可直接使用的响应头
+func runHTTPNegotiate(data []byte, filePath string) error {
+	qvalues := parseAcceptEncoding(acceptHeader)
+
+	var candidates []string
+	if len(qvalues) == 0 {
+		// 未提供Accept-Encoding时，退化为测试全部可上线编码
+		candidates = httpWireAlgorithms
+	} else {
+		wildcard, hasWildcard := qvalues["*"]
+		for _, algo := range httpWireAlgorithms {
+			q, ok := qvalues[algo]
+			if !ok {
+				if !hasWildcard {
+					continue
+				}
+				q = wildcard
+			}
+			if q <= 0 {
+				continue
+			}
+			candidates = append(candidates, algo)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("Accept-Encoding(%q)中没有本工具支持的可用编码", acceptHeader)
 	}
-	return b
+
+	results, err := measureCompressions(data, candidates, nil)
+	if err != nil {
+		return err
+	}
+
+	type scoredResult struct {
+		algo string
+		cost float64
+		r    result
+	}
+
+	var scored []scoredResult
+	for _, algo := range candidates {
+		r, ok := results[algo]
+		if !ok {
+			continue
+		}
+		cost := costAlpha*float64(r.CompressedSize) +
+			costBeta*r.Duration.Seconds()*1000 +
+			costGamma*r.DecompressDuration.Seconds()*1000
+		scored = append(scored, scoredResult{algo: algo, cost: cost, r: r})
+	}
+	sort.Slice(scored, func(i, j int) bool { return scored[i].cost < scored[j].cost })
+
+	if len(scored) == 0 {
+		return fmt.Errorf("候选编码均压缩失败")
+	}
+
+	fmt.Printf("\nHTTP Content-Encoding 协商 - 文件: %s (原始大小: %s)\n", filepath.Base(filePath), formatSize(len(data)))
+	if acceptHeader != "" {
+		fmt.Printf("Accept-Encoding: %s\n", acceptHeader)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "编码\tq权重\t压缩后大小\t压缩耗时\t解压耗时\t综合评分\t")
+	fmt.Fprintln(w, "----\t-----\t--------------\t--------\t--------\t--------")
+	for _, s := range scored {
+		qStr := "-"
+		if q, ok := qvalues[s.algo]; ok {
+			qStr = fmt.Sprintf("%.2f", q)
+		} else if q, ok := qvalues["*"]; ok {
+			qStr = fmt.Sprintf("%.2f", q)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1f ms\t%.1f ms\t%.1f\t\n",
+			getDisplayName(s.algo), qStr, formatSize(s.r.CompressedSize),
+			s.r.Duration.Seconds()*1000, s.r.DecompressDuration.Seconds()*1000, s.cost)
+	}
+	w.Flush()
+
+	winner := scored[0]
+	fmt.Printf("\n推荐编码: %s\n", getDisplayName(winner.algo))
+	fmt.Println("建议响应头:")
+	fmt.Printf("  Content-Encoding: %s\n", winner.algo)
+	fmt.Println("  Vary: Accept-Encoding")
+	fmt.Printf("  Content-Length: %d\n", winner.r.CompressedSize)
+
+	return nil
+}
+
+// newStreamWriter 为指定算法构造一个流式压缩Writer，写入的数据边压缩边转发到w
+func newStreamWriter(w io.Writer, algorithm string, level int) (io.WriteCloser, error) {
+	switch algorithm {
+	case "gzip":
+		if level >= 0 {
+			return gzip.NewWriterLevel(w, level)
+		}
+		return gzip.NewWriter(w), nil
+	case "zlib":
+		if level >= 0 {
+			return zlib.NewWriterLevel(w, level)
+		}
+		return zlib.NewWriter(w), nil
+	case "deflate":
+		if level >= 0 {
+			return flate.NewWriter(w, level)
+		}
+		return flate.NewWriter(w, flate.DefaultCompression)
+	case "br":
+		return brotli.NewWriterOptions(w, brotli.WriterOptions{Quality: level}), nil
+	case "zstd":
+		if level >= 0 {
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(w)
+	case "lz4":
+		return lz4.NewWriter(w), nil
+	case "snappy":
+		return s2.NewWriter(w), nil
+	case "lzw":
+		return lzw.NewWriter(w, lzw.LSB, 8), nil
+	case "bzip2":
+		opts := &bzip2.WriterConfig{}
+		if level >= 0 {
+			opts.Level = level
+		}
+		return bzip2.NewWriter(w, opts)
+	case "xz":
+		return xz.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("%s 不支持流式压缩", algorithm)
+	}
+}
+
+// countingWriter 只统计写入的字节数，用来衡量压缩后体积而不保留压缩数据本身
+type countingWriter struct {
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return len(p), nil
+}
+
+// streamStat 记录某个算法在流式压缩中的耗时分布、压缩后大小与堆内存增量
+type streamStat struct {
+	algo           string
+	chunkDurations []time.Duration
+	totalDuration  time.Duration
+	compressedSize int
+	heapAllocDelta uint64
+	err            error
+}
+
+// percentileDuration 返回已排序耗时切片中第p分位（0~1）的值
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
+
+// streamCompressOne 以固定大小的分块读取文件并送入算法的流式Writer，记录每个分块的
+// 写入耗时，用于估算p50/p95/p99延迟。
+// 注意: heapAllocDelta 是进程级别的全局堆快照差值，当 -jobs 大于1时会与其他并发
+// 运行的算法的分配互相干扰，此时该数值仅供参考，不代表单个算法的真实内存开销。
+func streamCompressOne(filePath, algo string) streamStat {
+	st := streamStat{algo: algo}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		st.err = err
+		return st
+	}
+	defer f.Close()
+
+	var memBefore, memAfter runtime.MemStats
+	runtime.ReadMemStats(&memBefore)
+
+	counter := &countingWriter{}
+	w, err := newStreamWriter(counter, algo, level)
+	if err != nil {
+		st.err = err
+		return st
+	}
+
+	buf := make([]byte, chunkSize)
+	start := time.Now()
+	for {
+		n, rerr := f.Read(buf)
+		if n > 0 {
+			chunkStart := time.Now()
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				st.err = werr
+				return st
+			}
+			st.chunkDurations = append(st.chunkDurations, time.Since(chunkStart))
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			st.err = rerr
+			return st
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		st.err = err
+		return st
+	}
+	st.totalDuration = time.Since(start)
+	st.compressedSize = counter.n
+
+	runtime.ReadMemStats(&memAfter)
+	if memAfter.HeapAlloc > memBefore.HeapAlloc {
+		st.heapAllocDelta = memAfter.HeapAlloc - memBefore.HeapAlloc
+	}
+
+	return st
+}
+
+// runStreamBenchmark 在-jobs个并发worker下，把文件按-chunk大小分块送入各算法的流式
+// Writer，汇总每种算法的总耗时、分块延迟分位数与堆内存增量
+func runStreamBenchmark(filePath string, algos []string) error {
+	if len(algos) == 0 {
+		return fmt.Errorf("没有可测试的算法")
+	}
+	if chunkSize <= 0 {
+		return fmt.Errorf("-chunk 必须是正整数，当前值: %d", chunkSize)
+	}
+	if jobs <= 0 {
+		return fmt.Errorf("-jobs 必须是正整数，当前值: %d", jobs)
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	if verbosity > 0 {
+		log.Printf("流式压缩: 分块大小=%s 并发数=%d", formatSize(chunkSize), jobs)
+	}
+
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	statsCh := make(chan streamStat, len(algos))
+
+	for _, algo := range algos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(algo string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			statsCh <- streamCompressOne(filePath, algo)
+		}(algo)
+	}
+	wg.Wait()
+	close(statsCh)
+
+	statsByAlgo := make(map[string]streamStat, len(algos))
+	for s := range statsCh {
+		statsByAlgo[s.algo] = s
+	}
+
+	fmt.Printf("\n流式压缩基准 - 文件: %s (大小: %s, 分块: %s, 并发: %d)\n",
+		filepath.Base(filePath), formatSize(int(info.Size())), formatSize(chunkSize), jobs)
+	if jobs > 1 {
+		fmt.Println("注意: -jobs > 1 时各算法并发运行，下表的堆内存增量为全局堆快照差值，会被其他并发算法的分配干扰，仅供参考")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "算法\t压缩后大小\t总耗时\tp50\tp95\tp99\t堆内存增量(并发下仅供参考)\t")
+	fmt.Fprintln(w, "----\t--------------\t--------\t----\t----\t----\t----------")
+
+	for _, algo := range algos {
+		st, ok := statsByAlgo[algo]
+		if !ok || st.err != nil {
+			if verbosity > 0 {
+				log.Printf("%s 流式压缩失败: %v", getDisplayName(algo), st.err)
+			}
+			continue
+		}
+
+		sorted := append([]time.Duration(nil), st.chunkDurations...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		p50 := percentileDuration(sorted, 0.50)
+		p95 := percentileDuration(sorted, 0.95)
+		p99 := percentileDuration(sorted, 0.99)
+
+		fmt.Fprintf(w, "%s\t%s\t%.1f ms\t%.2f ms\t%.2f ms\t%.2f ms\t%s\t\n",
+			getDisplayName(algo),
+			formatSize(st.compressedSize),
+			st.totalDuration.Seconds()*1000,
+			p50.Seconds()*1000,
+			p95.Seconds()*1000,
+			p99.Seconds()*1000,
+			formatSize(int(st.heapAllocDelta)),
+		)
+	}
+
+	return nil
 }
 
 // 结果处理和输出
 func printResults(
-	originalSize int, 
-	results map[string]result, 
-	testedAlgos []string, 
+	originalSize int,
+	results map[string]result,
+	testedAlgos []string,
 	supportedAlgos []string,
 	filePath string,
+	dict []byte,
 ) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 	defer w.Flush()
 
+	dictMode := len(dict) > 0
 	fileName := filepath.Base(filePath)
 	fmt.Printf("\n压缩效率比较 - 文件: %s (原始大小: %s)\n", fileName, formatSize(originalSize))
-	fmt.Fprintln(w, "算法名称\t压缩后大小\t压缩率\t节省空间\t耗时\t")
-	fmt.Fprintln(w, "---------\t--------------\t--------\t--------------\t--------")
+	if dictMode {
+		fmt.Printf("使用字典: 大小=%s\n", formatSize(len(dict)))
+		fmt.Fprintln(w, "算法名称\t无字典大小\t无字典压缩率\t含字典大小\t含字典压缩率\t耗时\t解压耗时\t校验\t")
+		fmt.Fprintln(w, "---------\t--------------\t------------\t--------------\t------------\t--------\t--------\t----")
+	} else {
+		fmt.Fprintln(w, "算法名称\t压缩后大小\t压缩率\t节省空间\t耗时\t解压耗时\t校验\t")
+		fmt.Fprintln(w, "---------\t--------------\t--------\t--------------\t--------\t--------\t----")
+	}
 
 	// 输出已测试的算法
 	for _, algo := range testedAlgos {
@@ -279,13 +1108,47 @@ func printResults(
 		savingsPercent := float64(savings) / float64(originalSize) * 100
 		durationMS := r.Duration.Seconds() * 1000
 
-		fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%s (%.1f%%)\t%.1f ms\t\n",
+		decompressStr := "-"
+		verifyStr := "-"
+		if r.VerifySupported {
+			decompressStr = fmt.Sprintf("%.1f ms", r.DecompressDuration.Seconds()*1000)
+			if r.Verified {
+				verifyStr = "✓"
+			} else {
+				verifyStr = "✗"
+			}
+		}
+
+		if dictMode {
+			dictSizeStr := "不支持"
+			dictRatioStr := "-"
+			if r.DictSupported {
+				dictSizeStr = formatSize(r.DictCompressedSize)
+				dictRatioStr = fmt.Sprintf("%.1f%%", r.DictRatio)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%s\t%s\t%.1f ms\t%s\t%s\t\n",
+				getDisplayName(algo),
+				formatSize(r.CompressedSize),
+				r.Ratio,
+				dictSizeStr,
+				dictRatioStr,
+				durationMS,
+				decompressStr,
+				verifyStr,
+			)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%.1f%%\t%s (%.1f%%)\t%.1f ms\t%s\t%s\t\n",
 			getDisplayName(algo),
 			formatSize(r.CompressedSize),
 			r.Ratio,
 			formatSize(savings),
 			savingsPercent,
 			durationMS,
+			decompressStr,
+			verifyStr,
 		)
 	}
 
@@ -328,16 +1191,10 @@ func getDisplayName(algo string) string {
 		return "Deflate"
 	case "lzw":
 		return "LZW"
-	case "lzo1":
-		return "LZO1"
-	case "lzo1a":
-		return "LZO1a"
-	case "lzo1b":
-		return "LZO1b"
-	case "lzo1c":
-		return "LZO1c"
-	case "lzo1f":
-		return "LZO1f"
+	case "bzip2":
+		return "Bzip2"
+	case "xz":
+		return "XZ/LZMA"
 	case "lzo1x":
 		return "LZO1x"
 	default:
@@ -365,7 +1222,7 @@ func saveCompressedData(data []byte, filePath string, results map[string]result)
 	for algo, result := range results {
 		ext := getExtension(algo)
 		outPath := filepath.Join(dir, fmt.Sprintf("%s%s", baseName, ext))
-		
+
 		if outputFile != "" {
 			outPath = outputFile
 		}
@@ -373,7 +1230,7 @@ func saveCompressedData(data []byte, filePath string, results map[string]result)
 		if err := os.WriteFile(outPath, result.CompressedData, 0644); err != nil {
 			log.Printf("无法写入 %s: %v", getDisplayName(algo), err)
 		} else if verbosity > 0 {
-			log.Printf("已保存: %s 压缩文件 -> %s (%s)", 
+			log.Printf("已保存: %s 压缩文件 -> %s (%s)",
 				getDisplayName(algo), outPath, formatSize(len(result.CompressedData)))
 		}
 	}
@@ -393,7 +1250,11 @@ func getExtension(algo string) string {
 		return ".snappy"
 	case "lzw":
 		return ".lzw"
-	case "lzo1", "lzo1a", "lzo1b", "lzo1c", "lzo1f", "lzo1x":
+	case "bzip2":
+		return ".bz2"
+	case "xz":
+		return ".xz"
+	case "lzo1x":
 		return ".lzo"
 	default:
 		return fmt.Sprintf(".%s", algo)
@@ -406,22 +1267,37 @@ func printHelp() {
 	fmt.Println("选项:")
 	flag.PrintDefaults()
 	fmt.Println("\n支持的压缩算法:")
-	fmt.Println("  全平台支持: gzip, zlib, deflate, br, zstd, lz4, snappy, lzw, lzo1, lzo1a, lzo1b, lzo1c, lzo1f, lzo1x")
+	fmt.Println("  全平台支持: gzip, zlib, deflate, br, zstd, lz4, snappy, lzw, bzip2, xz, lzo1x")
 	fmt.Println("\n注意:")
-	fmt.Println("  - LZO系列算法不准确")
+	fmt.Println("  - lzo1x为兼容liblzo2位流格式的LZO1X-1实现，不支持调整压缩级别")
 	fmt.Println("  - LZ4系列算法不支持调整压缩级别")
 	fmt.Println("  - 使用'-show-all'显示所有算法（包括平台不支持的）")
+	fmt.Println("  - 默认开启'-verify'对压缩结果进行解压校验，可用'-verify=false'关闭")
+	fmt.Println("  - '-http-negotiate'只测试可上线的编码(gzip/br/zstd/deflate/lz4/snappy)并给出推荐")
+	fmt.Println("  - '-stream'以流式分块方式压缩大文件，-chunk指定分块大小，-jobs指定并发算法数")
+	fmt.Println("  - lzo1x不支持流式压缩(自定义编解码器无Writer接口)")
+	fmt.Println("  - '-dict'/'-train'启用字典压缩对比，目前仅zstd支持字典(所用brotli库版本未暴露自定义字典API)")
 	fmt.Println("\n示例:")
 	fmt.Println("  compbench -i file.txt")
 	fmt.Println("  compbench -i file.log -l 9 -a gzip,br,zstd -o compressed.br")
 	fmt.Println("  compbench -i large.bin -v 1 -show-all")
+	fmt.Println("  compbench -i page.html -http-negotiate -accept \"br;q=1.0, zstd;q=0.9, gzip;q=0.5\"")
+	fmt.Println("  compbench -i large.bin -stream -chunk 524288 -jobs 4")
+	fmt.Println("  compbench -i sample.json -train 'samples/*.json' -dict sample.dict -a zstd")
 }
 
 type result struct {
-	CompressedSize int
-	Ratio          float64
-	Duration       time.Duration
-	CompressedData []byte
+	CompressedSize     int
+	Ratio              float64
+	Duration           time.Duration
+	CompressedData     []byte
+	DecompressDuration time.Duration
+	VerifySupported    bool
+	Verified           bool
+
+	DictSupported      bool
+	DictCompressedSize int
+	DictRatio          float64
 }
 
 // 压缩函数实现
@@ -435,19 +1311,19 @@ func gzipCompress(data []byte, level int) ([]byte, error) {
 	} else {
 		w = gzip.NewWriter(&buf)
 	}
-	
+
 	if err != nil {
 		return nil, err
 	}
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
 
@@ -461,19 +1337,19 @@ func zlibCompress(data []byte, level int) ([]byte, error) {
 	} else {
 		w = zlib.NewWriter(&buf)
 	}
-	
+
 	if err != nil {
 		return nil, err
 	}
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
 
@@ -487,19 +1363,19 @@ func deflateCompress(data []byte, level int) ([]byte, error) {
 	} else {
 		w, err = flate.NewWriter(&buf, flate.DefaultCompression)
 	}
-	
+
 	if err != nil {
 		return nil, err
 	}
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
 
@@ -507,15 +1383,15 @@ func brotliCompress(data []byte, level int) ([]byte, error) {
 	var buf bytes.Buffer
 	options := brotli.WriterOptions{Quality: level}
 	w := brotli.NewWriterOptions(&buf, options)
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
 
@@ -529,26 +1405,56 @@ func zstdCompress(data []byte, level int) ([]byte, error) {
 	} else {
 		w, err = zstd.NewWriter(&buf)
 	}
-	
+
 	if err != nil {
 		return nil, err
 	}
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
+	return buf.Bytes(), nil
+}
+
+// dictRawID 是WithEncoderDictRaw/WithDecoderDictRaw使用的固定字典ID。本工具的字典
+// 来自简单拼接的样本语料（不是"zstd --train"生成的带魔数的字典格式），因此用Raw变体
+// 把它当作原始历史数据使用，而不是WithEncoderDict/NewDict要求的正式字典格式
+const dictRawID = 1
+
+// zstdCompressWithDict 使用预训练字典压缩data，适合小体积、高度相似的样本(如日志行、
+// JSON记录)，字典消除了它们各自独立承担的公共前缀/结构开销
+func zstdCompressWithDict(data, dict []byte, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	opts := []zstd.EOption{zstd.WithEncoderDictRaw(dictRawID, dict)}
+	if level >= 0 {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+
+	w, err := zstd.NewWriter(&buf, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
 func lz4Compress(data []byte, level int) ([]byte, error) {
 	var buf bytes.Buffer
 	w := lz4.NewWriter(&buf)
-	
+
 	if level >= 0 {
 		if level > 9 {
 			level = 9
@@ -557,15 +1463,15 @@ func lz4Compress(data []byte, level int) ([]byte, error) {
 			CompressionLevel: level,
 		}*/
 	}
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
 
@@ -577,14 +1483,135 @@ func snappyCompress(data []byte) ([]byte, error) {
 func lzwCompress(data []byte) ([]byte, error) {
 	var buf bytes.Buffer
 	w := lzw.NewWriter(&buf, lzw.LSB, 8)
-	
+
 	if _, err := w.Write(data); err != nil {
 		return nil, err
 	}
-	
+
 	if err := w.Close(); err != nil {
 		return nil, err
 	}
-	
+
 	return buf.Bytes(), nil
 }
+
+func bzip2Compress(data []byte, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	opts := &bzip2.WriterConfig{}
+	if level >= 0 {
+		opts.Level = level
+	}
+
+	w, err := bzip2.NewWriter(&buf, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func xzCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := xz.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// 解压函数实现，用于往返校验
+func gzipDecompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func zlibDecompress(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func deflateDecompress(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func brotliDecompress(data []byte) ([]byte, error) {
+	r := brotli.NewReader(bytes.NewReader(data))
+	return io.ReadAll(r)
+}
+
+func zstdDecompress(data []byte) ([]byte, error) {
+	r, err := zstd.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func zstdDecompressWithDict(data, dict []byte) ([]byte, error) {
+	r, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderDictRaw(dictRawID, dict))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func lz4Decompress(data []byte) ([]byte, error) {
+	r := lz4.NewReader(bytes.NewReader(data))
+	return io.ReadAll(r)
+}
+
+func snappyDecompress(data []byte) ([]byte, error) {
+	return s2.Decode(nil, data)
+}
+
+func lzwDecompress(data []byte) ([]byte, error) {
+	r := lzw.NewReader(bytes.NewReader(data), lzw.LSB, 8)
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func bzip2Decompress(data []byte) ([]byte, error) {
+	r, err := bzip2.NewReader(bytes.NewReader(data), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func xzDecompress(data []byte) ([]byte, error) {
+	r, err := xz.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}